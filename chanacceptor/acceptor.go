@@ -0,0 +1,170 @@
+package chanacceptor
+
+import (
+	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// ChannelAcceptRequest is a struct containing the requesting node's public
+// key along with the lnwire.OpenChannel message that they sent when
+// requesting an inbound channel. This information is provided to each
+// Accept hook so that the acceptor can make its decision based on the
+// identity of the peer and the parameters of the proposed channel.
+type ChannelAcceptRequest struct {
+	// Node is the public key of the node requesting to open a channel.
+	Node *btcec.PublicKey
+
+	// OpenChanMsg is the actual OpenChannel protocol message that the
+	// peer sent to us.
+	OpenChanMsg *lnwire.OpenChannel
+
+	// PeerFeatures is the feature vector advertised by the peer in its
+	// Init message. It lets an acceptor condition its decision (for
+	// example, opting a channel into zero-conf or option_scid_alias) on
+	// what the peer is actually capable of supporting.
+	PeerFeatures *lnwire.FeatureVector
+}
+
+// ChannelAcceptResponse is the response a ChannelAcceptor gives to a
+// ChannelAcceptRequest. In addition to the binary accept/reject decision,
+// an acceptor may tighten the channel parameters that would otherwise be
+// plugged into our AcceptChannel reply, which lets the caller enforce
+// per-peer policy (e.g. a longer CSV delay or a larger reserve for
+// channels opened by an unknown or untrusted peer) instead of only being
+// able to veto the open outright.
+//
+// The zero value of every override field means "no override", and the
+// funding manager's usual default is used instead.
+type ChannelAcceptResponse struct {
+	// RejectChannel is set to true if the channel should be rejected.
+	RejectChannel bool
+
+	// RejectReason is sent back to the peer that proposed the channel
+	// if RejectChannel is true.
+	RejectReason string
+
+	// ZeroConf indicates that the channel should be treated as usable
+	// before it reaches MinAcceptDepth confirmations. When true, the
+	// funding manager skips the usual confirmation wait, generates an
+	// alias SCID for the channel, and routes over that alias until the
+	// funding transaction is buried. Only set it to true if the
+	// request's PeerFeatures indicates the peer supports zero-conf
+	// channels; Accept rejects the channel if it doesn't.
+	//
+	// Unlike the other override fields, a nil value (as opposed to a false one)
+	// means "no opinion": a ChainedAcceptor only lets a later acceptor's
+	// explicit true-or-false decision override an earlier one, rather
+	// than treating every unset field as an implicit veto.
+	ZeroConf *bool
+
+	// ScidAlias indicates that the channel should only ever be
+	// referenced by its alias SCID, and never by the confirmed, real
+	// SCID, even once the funding transaction is buried. Only set it to
+	// true if the request's PeerFeatures indicates the peer supports
+	// option_scid_alias; Accept rejects the channel if it doesn't. As
+	// with ZeroConf, nil means "no opinion".
+	ScidAlias *bool
+
+	// CSVDelay is the minimum number of blocks that the peer's
+	// commitment output must be delayed by, overriding the value we'd
+	// otherwise compute from our default policy.
+	CSVDelay uint16
+
+	// ReserveSat is the minimum amount, in satoshis, that the peer must
+	// keep as a direct balance on their commitment, overriding our
+	// usual percentage-of-capacity default.
+	ReserveSat btcutil.Amount
+
+	// InFlightMaxMsat is the maximum amount, in millisatoshis, that can
+	// be in flight on the channel at any given time.
+	InFlightMaxMsat lnwire.MilliSatoshi
+
+	// MaxHtlcCount is the maximum number of HTLCs that the peer may add
+	// to the commitment transaction at once.
+	MaxHtlcCount uint16
+
+	// MinHtlcIn is the smallest HTLC amount, in millisatoshis, that we
+	// will accept on this channel.
+	MinHtlcIn lnwire.MilliSatoshi
+
+	// MinAcceptDepth is the minimum number of confirmations that we
+	// require before the channel is marked as usable. It is ignored
+	// when ZeroConf is set.
+	MinAcceptDepth uint16
+
+	// UpfrontShutdown is the script that the peer has committed to
+	// using on cooperative close, if one was provided.
+	UpfrontShutdown lnwire.DeliveryAddress
+}
+
+// ChannelAcceptor is an interface that represents a predicate on the data
+// contained in ChannelAcceptRequest, evaluated by one of the mechanisms
+// available to the node, such as a user-provided RPC hook or a static,
+// config-driven policy.
+type ChannelAcceptor interface {
+	// Accept returns a response for the given request, indicating
+	// whether the channel should be accepted and any overrides that
+	// should be applied to its parameters.
+	Accept(req *ChannelAcceptRequest) *ChannelAcceptResponse
+}
+
+// RPCAcceptor is an implementation of the ChannelAcceptor interface that
+// lets this node query an external source on every remote channel open
+// request, and accept/reject based on that response.
+type RPCAcceptor struct {
+	receive func(req *ChannelAcceptRequest) (*lnrpc.ChannelAcceptResponse, error)
+}
+
+// NewRPCAcceptor creates and returns an instance of the RPCAcceptor.
+func NewRPCAcceptor(receive func(*ChannelAcceptRequest) (
+	*lnrpc.ChannelAcceptResponse, error)) *RPCAcceptor {
+
+	return &RPCAcceptor{
+		receive: receive,
+	}
+}
+
+// Accept is a predicate on the ChannelAcceptRequest which sends it to the
+// receive func and translates the lnrpc response (or error) it gets back
+// into a ChannelAcceptResponse.
+func (r *RPCAcceptor) Accept(req *ChannelAcceptRequest) *ChannelAcceptResponse {
+	resp, err := r.receive(req)
+	if err != nil {
+		return &ChannelAcceptResponse{
+			RejectChannel: true,
+			RejectReason:  err.Error(),
+		}
+	}
+
+	if !resp.Accept {
+		return &ChannelAcceptResponse{
+			RejectChannel: true,
+			RejectReason:  resp.GetRejectionReason(),
+		}
+	}
+
+	// Bound-check the overrides before narrowing them from the proto's
+	// uint32/uint64 fields to the uint16 fields ChannelAcceptResponse
+	// uses: validating only after the narrowing cast would let a value
+	// that overflows uint16 wrap around into something that passes.
+	if reason, ok := checkRPCOverrideRanges(resp); !ok {
+		return &ChannelAcceptResponse{
+			RejectChannel: true,
+			RejectReason:  reason,
+		}
+	}
+
+	return validateOverrides(req, &ChannelAcceptResponse{
+		ZeroConf:        resp.ZeroConf,
+		ScidAlias:       resp.ScidAlias,
+		CSVDelay:        uint16(resp.CsvDelay),
+		ReserveSat:      btcutil.Amount(resp.ReserveSat),
+		InFlightMaxMsat: lnwire.MilliSatoshi(resp.InFlightMaxMsat),
+		MaxHtlcCount:    uint16(resp.MaxHtlcCount),
+		MinHtlcIn:       lnwire.MilliSatoshi(resp.MinHtlcIn),
+		MinAcceptDepth:  uint16(resp.MinAcceptDepth),
+		UpfrontShutdown: lnwire.DeliveryAddress(resp.UpfrontShutdown),
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/lightningnetwork/lnd/lnrpc"
 
 	"github.com/btcsuite/btcd/btcec"
+	"github.com/btcsuite/btcutil"
 	"github.com/lightningnetwork/lnd/lnwire"
 )
 
@@ -36,7 +37,8 @@ var defaultAcceptTimeout = 5 * time.Second
 func acceptAndIncrementCtr(rpc ChannelAcceptor, req *ChannelAcceptRequest,
 	ctr *uint32, success chan struct{}) {
 
-	if err := rpc.Accept(req); err != nil {
+	resp := rpc.Accept(req)
+	if resp.RejectChannel {
 		return
 	}
 
@@ -87,7 +89,9 @@ func TestRPCMultipleAcceptClients(t *testing.T) {
 
 	// demultiplexReq is a closure used to abstract the RPCAcceptor's
 	// request and response logic.
-	demultiplexReq := func(req *ChannelAcceptRequest) error {
+	demultiplexReq := func(req *ChannelAcceptRequest) (
+		*lnrpc.ChannelAcceptResponse, error) {
+
 		respChan := make(chan lnrpc.ChannelAcceptResponse, 1)
 
 		newRequest := &requestInfo{
@@ -99,7 +103,7 @@ func TestRPCMultipleAcceptClients(t *testing.T) {
 		select {
 		case requests <- newRequest:
 		case <-quit:
-			return errors.New("quit")
+			return nil, errors.New("quit")
 		}
 
 		// Receive the response and verify that the PendingChanId
@@ -111,20 +115,16 @@ func TestRPCMultipleAcceptClients(t *testing.T) {
 			pendingID := req.OpenChanMsg.PendingChannelID
 			if !bytes.Equal(pendingID[:], resp.PendingChanId) {
 				errChan <- struct{}{}
-				return errors.New("PendingChanId doesn't " +
+				return nil, errors.New("PendingChanId doesn't " +
 					"match the ID in ChannelAcceptRequest")
 			}
 
-			if !resp.Accept {
-				return errors.New(resp.GetRejectionReason())
-			}
-
-			return nil
+			return &resp, nil
 		case <-time.After(defaultAcceptTimeout):
 			errChan <- struct{}{}
-			return errors.New("RPCAcceptor timed out")
+			return nil, errors.New("RPCAcceptor timed out")
 		case <-quit:
-			return errors.New("quit")
+			return nil, errors.New("quit")
 		}
 	}
 
@@ -162,3 +162,398 @@ func TestRPCMultipleAcceptClients(t *testing.T) {
 		}
 	}
 }
+
+// acceptorFunc adapts a plain function to the ChannelAcceptor interface,
+// for use in the ChainedAcceptor tests below.
+type acceptorFunc func(req *ChannelAcceptRequest) *ChannelAcceptResponse
+
+func (a acceptorFunc) Accept(req *ChannelAcceptRequest) *ChannelAcceptResponse {
+	return a(req)
+}
+
+// TestChainedAcceptorRejection asserts that the ChainedAcceptor rejects a
+// request as soon as any one of its acceptors rejects it, regardless of
+// the other acceptors' overrides.
+func TestChainedAcceptorRejection(t *testing.T) {
+	chained := NewChainedAcceptor()
+
+	chained.AddAcceptor(acceptorFunc(func(_ *ChannelAcceptRequest) *ChannelAcceptResponse {
+		return &ChannelAcceptResponse{
+			CSVDelay: 144,
+		}
+	}))
+	chained.AddAcceptor(acceptorFunc(func(_ *ChannelAcceptRequest) *ChannelAcceptResponse {
+		return &ChannelAcceptResponse{
+			RejectChannel: true,
+			RejectReason:  "rejected by policy",
+		}
+	}))
+
+	resp := chained.Accept(&ChannelAcceptRequest{})
+	if !resp.RejectChannel {
+		t.Fatalf("expected channel to be rejected")
+	}
+	if resp.RejectReason != "rejected by policy" {
+		t.Fatalf("unexpected reject reason: %v", resp.RejectReason)
+	}
+}
+
+// TestChainedAcceptorMerge asserts that the ChainedAcceptor merges the
+// overrides of every acceptor that accepts the request, with later
+// acceptors taking precedence over earlier ones.
+func TestChainedAcceptorMerge(t *testing.T) {
+	chained := NewChainedAcceptor()
+
+	chained.AddAcceptor(acceptorFunc(func(_ *ChannelAcceptRequest) *ChannelAcceptResponse {
+		return &ChannelAcceptResponse{
+			CSVDelay:   144,
+			ReserveSat: 10000,
+		}
+	}))
+	chained.AddAcceptor(acceptorFunc(func(_ *ChannelAcceptRequest) *ChannelAcceptResponse {
+		return &ChannelAcceptResponse{
+			ReserveSat:   20000,
+			MaxHtlcCount: 20,
+		}
+	}))
+
+	resp := chained.Accept(&ChannelAcceptRequest{})
+	if resp.RejectChannel {
+		t.Fatalf("expected channel to be accepted")
+	}
+	if resp.CSVDelay != 144 {
+		t.Fatalf("expected CSVDelay 144, got %v", resp.CSVDelay)
+	}
+	if resp.ReserveSat != 20000 {
+		t.Fatalf("expected the second acceptor's ReserveSat to win, "+
+			"got %v", resp.ReserveSat)
+	}
+	if resp.MaxHtlcCount != 20 {
+		t.Fatalf("expected MaxHtlcCount 20, got %v", resp.MaxHtlcCount)
+	}
+}
+
+// TestValidateOverrides runs table-driven tests asserting that an
+// override violating a protocol bound is turned into a rejection instead
+// of being passed through.
+func TestValidateOverrides(t *testing.T) {
+	req := &ChannelAcceptRequest{
+		OpenChanMsg: &lnwire.OpenChannel{
+			FundingAmount: 100000,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		resp    *ChannelAcceptResponse
+		wantErr bool
+	}{
+		{
+			name:    "no overrides",
+			resp:    &ChannelAcceptResponse{},
+			wantErr: false,
+		},
+		{
+			name: "csv delay within bounds",
+			resp: &ChannelAcceptResponse{
+				CSVDelay: maxCSVDelay,
+			},
+			wantErr: false,
+		},
+		{
+			name: "csv delay exceeds bound",
+			resp: &ChannelAcceptResponse{
+				CSVDelay: maxCSVDelay + 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "htlc count exceeds bound",
+			resp: &ChannelAcceptResponse{
+				MaxHtlcCount: maxHtlcCount + 1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "reserve exceeds channel capacity",
+			resp: &ChannelAcceptResponse{
+				ReserveSat: btcutil.Amount(200000),
+			},
+			wantErr: true,
+		},
+		{
+			name: "reserve within channel capacity",
+			resp: &ChannelAcceptResponse{
+				ReserveSat: btcutil.Amount(50000),
+			},
+			wantErr: false,
+		},
+		{
+			name: "already rejected is left alone",
+			resp: &ChannelAcceptResponse{
+				RejectChannel: true,
+				RejectReason:  "policy",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got := validateOverrides(req, test.resp)
+			if got.RejectChannel != test.wantErr {
+				t.Fatalf("expected RejectChannel=%v, got %v "+
+					"(reason: %v)", test.wantErr,
+					got.RejectChannel, got.RejectReason)
+			}
+		})
+	}
+}
+
+// zeroConfCapableFeatures returns a feature vector advertising support for
+// both zero-conf channels and option_scid_alias.
+func zeroConfCapableFeatures() *lnwire.FeatureVector {
+	raw := lnwire.NewRawFeatureVector(
+		lnwire.ZeroConfOptional, lnwire.ScidAliasOptional,
+	)
+
+	return lnwire.NewFeatureVector(raw, lnwire.Features)
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// TestChainedAcceptorZeroConf asserts that a ZeroConf or ScidAlias
+// override from any one acceptor sticks, as long as a later acceptor
+// doesn't explicitly weigh in.
+func TestChainedAcceptorZeroConf(t *testing.T) {
+	chained := NewChainedAcceptor()
+
+	chained.AddAcceptor(acceptorFunc(func(_ *ChannelAcceptRequest) *ChannelAcceptResponse {
+		return &ChannelAcceptResponse{
+			ZeroConf:  boolPtr(true),
+			ScidAlias: boolPtr(true),
+		}
+	}))
+	chained.AddAcceptor(acceptorFunc(func(_ *ChannelAcceptRequest) *ChannelAcceptResponse {
+		return &ChannelAcceptResponse{}
+	}))
+
+	req := &ChannelAcceptRequest{PeerFeatures: zeroConfCapableFeatures()}
+
+	resp := chained.Accept(req)
+	if resp.RejectChannel {
+		t.Fatalf("expected channel to be accepted, got reason: %v",
+			resp.RejectReason)
+	}
+	if resp.ZeroConf == nil || !*resp.ZeroConf {
+		t.Fatalf("expected ZeroConf to be preserved across the chain")
+	}
+	if resp.ScidAlias == nil || !*resp.ScidAlias {
+		t.Fatalf("expected ScidAlias to be preserved across the chain")
+	}
+}
+
+// TestChainedAcceptorZeroConfVeto asserts that a later acceptor can veto
+// an earlier one's zero-conf opt-in by explicitly returning false, the
+// same way it can tighten any other override.
+func TestChainedAcceptorZeroConfVeto(t *testing.T) {
+	chained := NewChainedAcceptor()
+
+	chained.AddAcceptor(acceptorFunc(func(_ *ChannelAcceptRequest) *ChannelAcceptResponse {
+		return &ChannelAcceptResponse{
+			ZeroConf: boolPtr(true),
+		}
+	}))
+	chained.AddAcceptor(acceptorFunc(func(_ *ChannelAcceptRequest) *ChannelAcceptResponse {
+		return &ChannelAcceptResponse{
+			ZeroConf: boolPtr(false),
+		}
+	}))
+
+	req := &ChannelAcceptRequest{PeerFeatures: zeroConfCapableFeatures()}
+
+	resp := chained.Accept(req)
+	if resp.RejectChannel {
+		t.Fatalf("expected channel to be accepted, got reason: %v",
+			resp.RejectReason)
+	}
+	if resp.ZeroConf == nil || *resp.ZeroConf {
+		t.Fatalf("expected the later acceptor's veto to win")
+	}
+}
+
+// TestChainedAcceptorZeroConfUnsupported asserts that a zero-conf opt-in
+// is rejected outright if the peer didn't advertise support for it.
+func TestChainedAcceptorZeroConfUnsupported(t *testing.T) {
+	chained := NewChainedAcceptor()
+
+	chained.AddAcceptor(acceptorFunc(func(_ *ChannelAcceptRequest) *ChannelAcceptResponse {
+		return &ChannelAcceptResponse{
+			ZeroConf: boolPtr(true),
+		}
+	}))
+
+	resp := chained.Accept(&ChannelAcceptRequest{})
+	if !resp.RejectChannel {
+		t.Fatalf("expected channel to be rejected, peer did not " +
+			"advertise zero-conf support")
+	}
+}
+
+// TestStaticAcceptor runs table-driven tests over each rule that a
+// StaticAcceptor can enforce, checking that it rejects a request if and
+// only if the relevant rule is violated.
+func TestStaticAcceptor(t *testing.T) {
+	node := randKey(t)
+
+	var pubKey [33]byte
+	copy(pubKey[:], node.SerializeCompressed())
+
+	var otherPubKey [33]byte
+	copy(otherPubKey[:], randKey(t).SerializeCompressed())
+
+	baseReq := func() *ChannelAcceptRequest {
+		return &ChannelAcceptRequest{
+			Node: node,
+			OpenChanMsg: &lnwire.OpenChannel{
+				FundingAmount: 100000,
+				ChannelFlags:  lnwire.FFAnnounceChannel,
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		cfg     StaticAcceptorConfig
+		req     *ChannelAcceptRequest
+		wantErr bool
+	}{
+		{
+			name:    "no rules configured",
+			cfg:     StaticAcceptorConfig{},
+			req:     baseReq(),
+			wantErr: false,
+		},
+		{
+			name: "channel too small",
+			cfg: StaticAcceptorConfig{
+				MinChanSize: 200000,
+			},
+			req:     baseReq(),
+			wantErr: true,
+		},
+		{
+			name: "channel too large",
+			cfg: StaticAcceptorConfig{
+				MaxChanSize: 50000,
+			},
+			req:     baseReq(),
+			wantErr: true,
+		},
+		{
+			name: "node on deny list",
+			cfg: StaticAcceptorConfig{
+				DeniedNodes: map[[33]byte]struct{}{
+					pubKey: {},
+				},
+			},
+			req:     baseReq(),
+			wantErr: true,
+		},
+		{
+			name: "node not on allow list",
+			cfg: StaticAcceptorConfig{
+				AllowedNodes: map[[33]byte]struct{}{
+					otherPubKey: {},
+				},
+			},
+			req:     baseReq(),
+			wantErr: true,
+		},
+		{
+			name: "node on allow list",
+			cfg: StaticAcceptorConfig{
+				AllowedNodes: map[[33]byte]struct{}{
+					pubKey: {},
+				},
+			},
+			req:     baseReq(),
+			wantErr: false,
+		},
+		{
+			name: "too many pending channels",
+			cfg: StaticAcceptorConfig{
+				MaxPendingChansPerPeer: 1,
+				NumPendingChans: func(_ [33]byte) int {
+					return 1
+				},
+			},
+			req:     baseReq(),
+			wantErr: true,
+		},
+		{
+			name: "peer unknown",
+			cfg: StaticAcceptorConfig{
+				MinPeerAge: time.Hour,
+				PeerAge: func(_ [33]byte) (time.Duration, bool) {
+					return 0, false
+				},
+			},
+			req:     baseReq(),
+			wantErr: true,
+		},
+		{
+			name: "peer too new",
+			cfg: StaticAcceptorConfig{
+				MinPeerAge: time.Hour,
+				PeerAge: func(_ [33]byte) (time.Duration, bool) {
+					return time.Minute, true
+				},
+			},
+			req:     baseReq(),
+			wantErr: true,
+		},
+		{
+			name: "peer old enough",
+			cfg: StaticAcceptorConfig{
+				MinPeerAge: time.Hour,
+				PeerAge: func(_ [33]byte) (time.Duration, bool) {
+					return 2 * time.Hour, true
+				},
+			},
+			req:     baseReq(),
+			wantErr: false,
+		},
+		{
+			name: "private channels disabled",
+			cfg: StaticAcceptorConfig{
+				DisablePrivateChannels: true,
+			},
+			req: &ChannelAcceptRequest{
+				Node: node,
+				OpenChanMsg: &lnwire.OpenChannel{
+					FundingAmount: 100000,
+					ChannelFlags:  0,
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			acceptor := NewStaticAcceptor(test.cfg)
+			resp := acceptor.Accept(test.req)
+
+			if resp.RejectChannel != test.wantErr {
+				t.Fatalf("expected RejectChannel=%v, got %v "+
+					"(reason: %v)", test.wantErr,
+					resp.RejectChannel, resp.RejectReason)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package chanacceptor
+
+import (
+	"sort"
+	"sync"
+)
+
+// ChainedAcceptor holds a set of ChannelAcceptors and evaluates all of
+// them, in the deterministic order they were registered in, to produce a
+// single ChannelAcceptResponse for a ChannelAcceptRequest. This allows
+// the RPC-driven acceptor, which only has an effect while a client is
+// subscribed to it, to be combined with always-on policy acceptors such
+// as a static, config-driven one.
+type ChainedAcceptor struct {
+	mu sync.RWMutex
+
+	acceptors map[uint64]ChannelAcceptor
+	nextID    uint64
+}
+
+// NewChainedAcceptor creates a new, empty ChainedAcceptor.
+func NewChainedAcceptor() *ChainedAcceptor {
+	return &ChainedAcceptor{
+		acceptors: make(map[uint64]ChannelAcceptor),
+	}
+}
+
+// AddAcceptor adds a ChannelAcceptor to this ChainedAcceptor, returning an
+// ID that can be used to remove it again with RemoveAcceptor. Acceptors
+// are evaluated in the order in which they were added.
+func (c *ChainedAcceptor) AddAcceptor(acceptor ChannelAcceptor) uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	id := c.nextID
+	c.nextID++
+
+	c.acceptors[id] = acceptor
+
+	return id
+}
+
+// RemoveAcceptor removes the ChannelAcceptor identified by id, previously
+// returned by AddAcceptor, from this ChainedAcceptor.
+func (c *ChainedAcceptor) RemoveAcceptor(id uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.acceptors, id)
+}
+
+// Accept evaluates every registered ChannelAcceptor against req, in
+// ascending order of registration. The first rejection encountered
+// short-circuits the remaining acceptors and is returned as-is, so that
+// the rejecting acceptor's reason is preserved. Otherwise, the non-zero
+// overrides from every acceptor's response are folded into a single
+// response, with later acceptors taking precedence over earlier ones for
+// any field they override.
+func (c *ChainedAcceptor) Accept(req *ChannelAcceptRequest) *ChannelAcceptResponse {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ids := make([]uint64, 0, len(c.acceptors))
+	for id := range c.acceptors {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+
+	merged := &ChannelAcceptResponse{}
+	for _, id := range ids {
+		resp := c.acceptors[id].Accept(req)
+		if resp.RejectChannel {
+			return resp
+		}
+
+		mergeAcceptResponses(merged, resp)
+	}
+
+	// Acceptors other than the RPCAcceptor don't validate their own
+	// overrides, so the merged result is checked against the protocol
+	// bounds here before it's returned.
+	return validateOverrides(req, merged)
+}
+
+// mergeAcceptResponses folds the overrides set on src into dst, with any
+// non-zero (or, for ZeroConf/ScidAlias, non-nil) field on src taking
+// precedence over whatever dst already held. This lets a later acceptor
+// veto an earlier one's zero-conf or scid-alias opt-in by explicitly
+// returning false, the same way it can tighten any other override -
+// provided it actually has an opinion; an acceptor that leaves the field
+// nil passes the earlier decision through untouched.
+func mergeAcceptResponses(dst, src *ChannelAcceptResponse) {
+	if src.ZeroConf != nil {
+		dst.ZeroConf = src.ZeroConf
+	}
+	if src.ScidAlias != nil {
+		dst.ScidAlias = src.ScidAlias
+	}
+	if src.CSVDelay != 0 {
+		dst.CSVDelay = src.CSVDelay
+	}
+	if src.ReserveSat != 0 {
+		dst.ReserveSat = src.ReserveSat
+	}
+	if src.InFlightMaxMsat != 0 {
+		dst.InFlightMaxMsat = src.InFlightMaxMsat
+	}
+	if src.MaxHtlcCount != 0 {
+		dst.MaxHtlcCount = src.MaxHtlcCount
+	}
+	if src.MinHtlcIn != 0 {
+		dst.MinHtlcIn = src.MinHtlcIn
+	}
+	if src.MinAcceptDepth != 0 {
+		dst.MinAcceptDepth = src.MinAcceptDepth
+	}
+	if len(src.UpfrontShutdown) != 0 {
+		dst.UpfrontShutdown = src.UpfrontShutdown
+	}
+}
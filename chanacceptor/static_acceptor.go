@@ -0,0 +1,142 @@
+package chanacceptor
+
+import (
+	"time"
+
+	"github.com/btcsuite/btcutil"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+// StaticAcceptorConfig holds the set of rules that a StaticAcceptor
+// enforces. It is typically populated from lnd.conf, or a reloadable
+// policy file, and takes effect without any external process (such as an
+// RPC client) needing to be connected.
+type StaticAcceptorConfig struct {
+	// MinChanSize is the minimum channel size, in satoshis, that this
+	// node is willing to accept. A value of 0 disables the check.
+	MinChanSize btcutil.Amount
+
+	// MaxChanSize is the maximum channel size, in satoshis, that this
+	// node is willing to accept. A value of 0 disables the check.
+	MaxChanSize btcutil.Amount
+
+	// AllowedNodes, if non-empty, is the exhaustive set of peers that
+	// are allowed to open channels to us. Any peer not present in this
+	// set is rejected.
+	AllowedNodes map[[33]byte]struct{}
+
+	// DeniedNodes is the set of peers that are never allowed to open a
+	// channel to us, regardless of AllowedNodes.
+	DeniedNodes map[[33]byte]struct{}
+
+	// MaxPendingChansPerPeer caps the number of channels that a single
+	// peer may have pending with us at once. A value of 0 disables the
+	// check.
+	MaxPendingChansPerPeer int
+
+	// MinPeerAge is the minimum duration that we must have known a peer
+	// for before we will accept an inbound channel from them. A value
+	// of 0 disables the check.
+	MinPeerAge time.Duration
+
+	// DisablePrivateChannels rejects channels that the peer did not
+	// request to announce.
+	DisablePrivateChannels bool
+
+	// NumPendingChans, if set, returns the number of channels that are
+	// currently pending with the given peer. It is queried rather than
+	// threaded through statically so the StaticAcceptor always reflects
+	// live funding manager state.
+	NumPendingChans func(peer [33]byte) int
+
+	// PeerAge, if set, returns how long we have known the given peer
+	// for. The bool return value is false if the peer has never been
+	// seen before.
+	PeerAge func(peer [33]byte) (time.Duration, bool)
+}
+
+// StaticAcceptor is a ChannelAcceptor that evaluates a fixed set of rules
+// sourced from configuration, without requiring any external process to
+// be attached. Composed with an RPCAcceptor in a ChainedAcceptor, it
+// provides sensible default gating even when no RPC client is connected.
+type StaticAcceptor struct {
+	cfg StaticAcceptorConfig
+}
+
+// NewStaticAcceptor creates a new StaticAcceptor from the given config.
+func NewStaticAcceptor(cfg StaticAcceptorConfig) *StaticAcceptor {
+	return &StaticAcceptor{
+		cfg: cfg,
+	}
+}
+
+// Accept enforces every configured rule against req, rejecting the
+// channel on the first rule that is violated. A StaticAcceptor never
+// overrides channel parameters itself; it only vetoes.
+func (s *StaticAcceptor) Accept(req *ChannelAcceptRequest) *ChannelAcceptResponse {
+	if reason, ok := s.checkRules(req); !ok {
+		return &ChannelAcceptResponse{
+			RejectChannel: true,
+			RejectReason:  reason,
+		}
+	}
+
+	return &ChannelAcceptResponse{}
+}
+
+// checkRules returns false and a human-readable reason if req violates
+// any of the rules in the StaticAcceptor's config.
+func (s *StaticAcceptor) checkRules(req *ChannelAcceptRequest) (string, bool) {
+	chanSize := req.OpenChanMsg.FundingAmount
+
+	if s.cfg.MinChanSize != 0 && chanSize < s.cfg.MinChanSize {
+		return "channel size is below the minimum we accept", false
+	}
+
+	if s.cfg.MaxChanSize != 0 && chanSize > s.cfg.MaxChanSize {
+		return "channel size is above the maximum we accept", false
+	}
+
+	var pubKey [33]byte
+	copy(pubKey[:], req.Node.SerializeCompressed())
+
+	if _, denied := s.cfg.DeniedNodes[pubKey]; denied {
+		return "node is on the deny list", false
+	}
+
+	if len(s.cfg.AllowedNodes) != 0 {
+		if _, allowed := s.cfg.AllowedNodes[pubKey]; !allowed {
+			return "node is not on the allow list", false
+		}
+	}
+
+	if s.cfg.MaxPendingChansPerPeer != 0 && s.cfg.NumPendingChans != nil {
+		if s.cfg.NumPendingChans(pubKey) >= s.cfg.MaxPendingChansPerPeer {
+			return "too many pending channels with this peer", false
+		}
+	}
+
+	if s.cfg.MinPeerAge != 0 && s.cfg.PeerAge != nil {
+		age, known := s.cfg.PeerAge(pubKey)
+		if !known || age < s.cfg.MinPeerAge {
+			return "peer is not known for long enough", false
+		}
+	}
+
+	isPrivate := req.OpenChanMsg.ChannelFlags&lnwire.FFAnnounceChannel == 0
+	if s.cfg.DisablePrivateChannels && isPrivate {
+		return "private channels are disabled", false
+	}
+
+	return "", true
+}
+
+// Note: an earlier version of this file also rejected channels whose peer
+// had not negotiated "require confirmed inputs". That isn't something a
+// ChannelAcceptRequest can answer: require-confirmed-inputs is agreed per
+// input during interactive (v2) transaction construction, not encoded as
+// a bit on the v1 open_channel message's channel_type field that
+// ChannelAcceptRequest.OpenChanMsg carries, and lnwire has no such
+// channel-type constant. The rule was dropped rather than checking the
+// wrong thing; it can come back once ChannelAcceptRequest is extended to
+// carry the interactive funding (open_channel2) parameters.
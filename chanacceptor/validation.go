@@ -0,0 +1,133 @@
+package chanacceptor
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/lightningnetwork/lnd/lnrpc"
+	"github.com/lightningnetwork/lnd/lnwire"
+)
+
+const (
+	// maxCSVDelay is the most blocks we will ever let an acceptor ask a
+	// peer to delay their commitment output by. Well beyond this, a
+	// channel becomes impractical to unilaterally close within a
+	// reasonable time, so no override is allowed past it regardless of
+	// how a particular acceptor is configured.
+	maxCSVDelay = 2016
+
+	// maxHtlcCount is the hard limit BOLT 2 places on the number of
+	// HTLCs a commitment transaction may carry.
+	maxHtlcCount = 483
+
+	// maxMinAcceptDepth is the most confirmations we will ever require
+	// before treating a channel as usable. Beyond this, the wait
+	// becomes pointless for an override meant to harden policy rather
+	// than stall the channel indefinitely.
+	maxMinAcceptDepth = 2016
+)
+
+// validateOverrides checks resp's overrides against the protocol bounds
+// that are derivable from req alone (the negotiated channel capacity and
+// BOLT 2's fixed limits), returning a rejecting response in place of resp
+// if any bound is violated. Operator-configured bounds that depend on
+// node-wide policy (for example, a configured CSV delay ceiling) are
+// applied by the funding manager once it receives this response and
+// plugs it into its AcceptChannel reply; that wiring lives outside this
+// chunk of the tree.
+func validateOverrides(req *ChannelAcceptRequest,
+	resp *ChannelAcceptResponse) *ChannelAcceptResponse {
+
+	if resp.RejectChannel {
+		return resp
+	}
+
+	reject := func(reason string) *ChannelAcceptResponse {
+		return &ChannelAcceptResponse{
+			RejectChannel: true,
+			RejectReason:  reason,
+		}
+	}
+
+	switch {
+	case resp.CSVDelay > maxCSVDelay:
+		return reject(fmt.Sprintf("csv delay override %v exceeds "+
+			"the maximum of %v", resp.CSVDelay, maxCSVDelay))
+
+	case resp.MaxHtlcCount > maxHtlcCount:
+		return reject(fmt.Sprintf("max htlc count override %v "+
+			"exceeds the protocol limit of %v", resp.MaxHtlcCount,
+			maxHtlcCount))
+
+	case resp.MinAcceptDepth > maxMinAcceptDepth:
+		return reject(fmt.Sprintf("min accept depth override %v "+
+			"exceeds the maximum of %v", resp.MinAcceptDepth,
+			maxMinAcceptDepth))
+
+	case resp.ReserveSat != 0 && req.OpenChanMsg != nil &&
+		resp.ReserveSat > req.OpenChanMsg.FundingAmount:
+
+		return reject(fmt.Sprintf("reserve override %v exceeds the "+
+			"channel capacity %v", resp.ReserveSat,
+			req.OpenChanMsg.FundingAmount))
+
+	case resp.ZeroConf != nil && *resp.ZeroConf && !requestSupportsZeroConf(req):
+		return reject("cannot opt into zero-conf: peer did not " +
+			"advertise support for it")
+
+	case resp.ScidAlias != nil && *resp.ScidAlias && !requestSupportsScidAlias(req):
+		return reject("cannot opt into scid-alias: peer did not " +
+			"advertise support for it")
+	}
+
+	return resp
+}
+
+// checkRPCOverrideRanges checks resp's CsvDelay, MaxHtlcCount,
+// MinAcceptDepth and ReserveSat against the protocol bounds - and, for
+// ReserveSat, the range of the type it's converted to - before
+// RPCAcceptor narrows/converts them to ChannelAcceptResponse's fields.
+// Doing the check before that conversion is what catches an out-of-range
+// value instead of letting it wrap around (uint32 -> uint16) or flip
+// negative (uint64 -> int64) into something that happens to pass.
+func checkRPCOverrideRanges(resp *lnrpc.ChannelAcceptResponse) (string, bool) {
+	if resp.CsvDelay > maxCSVDelay {
+		return fmt.Sprintf("csv delay override %v exceeds the "+
+			"maximum of %v", resp.CsvDelay, maxCSVDelay), false
+	}
+
+	if resp.MaxHtlcCount > maxHtlcCount {
+		return fmt.Sprintf("max htlc count override %v exceeds the "+
+			"protocol limit of %v", resp.MaxHtlcCount,
+			maxHtlcCount), false
+	}
+
+	if resp.MinAcceptDepth > maxMinAcceptDepth {
+		return fmt.Sprintf("min accept depth override %v exceeds "+
+			"the maximum of %v", resp.MinAcceptDepth,
+			maxMinAcceptDepth), false
+	}
+
+	if resp.ReserveSat > math.MaxInt64 {
+		return fmt.Sprintf("reserve override %v does not fit in a "+
+			"signed satoshi amount", resp.ReserveSat), false
+	}
+
+	return "", true
+}
+
+// requestSupportsZeroConf returns true if the peer's advertised features
+// include support for zero-conf channels.
+func requestSupportsZeroConf(req *ChannelAcceptRequest) bool {
+	return req.PeerFeatures != nil &&
+		(req.PeerFeatures.HasFeature(lnwire.ZeroConfOptional) ||
+			req.PeerFeatures.HasFeature(lnwire.ZeroConfRequired))
+}
+
+// requestSupportsScidAlias returns true if the peer's advertised features
+// include support for option_scid_alias.
+func requestSupportsScidAlias(req *ChannelAcceptRequest) bool {
+	return req.PeerFeatures != nil &&
+		(req.PeerFeatures.HasFeature(lnwire.ScidAliasOptional) ||
+			req.PeerFeatures.HasFeature(lnwire.ScidAliasRequired))
+}